@@ -0,0 +1,182 @@
+// Package auth provides JWT-based authentication and tenant-scoped
+// authorization for the API: issuing tokens on login/register, and a
+// RequireAuth middleware that injects the caller's identity into the
+// request context.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/crypto/bcrypt"
+
+	"small-backend/obs"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrEmailTaken         = errors.New("email already registered")
+)
+
+type ctxKey string
+
+const (
+	userIDKey   ctxKey = "auth.user_id"
+	tenantIDKey ctxKey = "auth.tenant_id"
+)
+
+// User is a registered account, scoped to a single tenant.
+type User struct {
+	ID        string    `json:"id"`
+	TenantID  string    `json:"tenant_id"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Claims are the custom JWT claims issued on login/register.
+type Claims struct {
+	UserID   string `json:"user_id"`
+	TenantID string `json:"tenant_id"`
+	jwt.RegisteredClaims
+}
+
+// Service issues and validates JWTs and manages user credentials.
+type Service struct {
+	db         *pgxpool.Pool
+	signingKey []byte
+	tokenTTL   time.Duration
+}
+
+// NewService builds an auth Service. signingKey must be non-empty; tokenTTL
+// is the lifetime applied to newly issued tokens.
+func NewService(db *pgxpool.Pool, signingKey []byte, tokenTTL time.Duration) *Service {
+	return &Service{db: db, signingKey: signingKey, tokenTTL: tokenTTL}
+}
+
+// Register creates a new user under tenantID and returns a signed token.
+func (s *Service) Register(ctx context.Context, tenantID, email, password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("hash password: %w", err)
+	}
+
+	var userID string
+	err = s.db.QueryRow(ctx, `
+		INSERT INTO users (tenant_id, email, password_hash)
+		VALUES ($1, $2, $3)
+		RETURNING id::text`,
+		tenantID, email, string(hash),
+	).Scan(&userID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return "", ErrEmailTaken
+		}
+		return "", fmt.Errorf("insert user: %w", err)
+	}
+
+	return s.issueToken(userID, tenantID)
+}
+
+// Login verifies email/password and returns a signed token on success.
+func (s *Service) Login(ctx context.Context, email, password string) (string, error) {
+	var userID, tenantID, hash string
+	err := s.db.QueryRow(ctx, `
+		SELECT id::text, tenant_id::text, password_hash
+		FROM users
+		WHERE email = $1`,
+		email,
+	).Scan(&userID, &tenantID, &hash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrInvalidCredentials
+	}
+	if err != nil {
+		return "", fmt.Errorf("query user: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return "", ErrInvalidCredentials
+	}
+
+	return s.issueToken(userID, tenantID)
+}
+
+func (s *Service) issueToken(userID, tenantID string) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		UserID:   userID,
+		TenantID: tenantID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.tokenTTL)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(s.signingKey)
+}
+
+// RequireAuth parses the Authorization header, validates the token, and
+// injects user_id/tenant_id into the request context. Requests without a
+// valid token are rejected with 401.
+func (s *Service) RequireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, err := bearerToken(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		claims := &Claims{}
+		token, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (any, error) {
+			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+			}
+			return s.signingKey, nil
+		})
+		if err != nil || !token.Valid {
+			http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), userIDKey, claims.UserID)
+		ctx = context.WithValue(ctx, tenantIDKey, claims.TenantID)
+		obs.SetTenant(ctx, claims.TenantID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func bearerToken(r *http.Request) (string, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return "", errors.New("missing Authorization header")
+	}
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return "", errors.New("Authorization header must be a Bearer token")
+	}
+	return parts[1], nil
+}
+
+// UserIDFromContext returns the authenticated user_id injected by RequireAuth.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(userIDKey).(string)
+	return v, ok
+}
+
+// TenantIDFromContext returns the authenticated tenant_id injected by RequireAuth.
+func TenantIDFromContext(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(tenantIDKey).(string)
+	return v, ok
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
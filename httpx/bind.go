@@ -0,0 +1,163 @@
+package httpx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidationError describes a single struct-tag validation failure.
+type ValidationError struct {
+	Field string
+	Rule  string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: failed %q validation", e.Field, e.Rule)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Bind decodes a JSON body (if present), overlays query and path values, and
+// validates the result into a new T using the struct tags `json:"..."`,
+// `query:"..."`, `path:"..."`, and `validate:"..."`. Query and path values
+// are only applied to fields that don't already have a tag-matching JSON
+// value, and are parsed according to the field's Go type (string, bool, any
+// int kind, or time.Time/*time.Time for RFC3339 timestamps).
+func Bind[T any](r *http.Request) (T, error) {
+	var out T
+
+	if r.Body != nil && r.ContentLength != 0 {
+		dec := json.NewDecoder(r.Body)
+		if err := dec.Decode(&out); err != nil && err != io.EOF {
+			return out, fmt.Errorf("invalid json: %w", err)
+		}
+	}
+
+	v := reflect.ValueOf(&out).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+
+		if tag, ok := field.Tag.Lookup("query"); ok {
+			if raw := r.URL.Query().Get(tag); raw != "" && fv.IsZero() {
+				if err := setField(fv, raw); err != nil {
+					return out, fmt.Errorf("query %q: %w", tag, err)
+				}
+			}
+		}
+		if tag, ok := field.Tag.Lookup("path"); ok {
+			if raw := r.PathValue(tag); raw != "" && fv.IsZero() {
+				if err := setField(fv, raw); err != nil {
+					return out, fmt.Errorf("path %q: %w", tag, err)
+				}
+			}
+		}
+	}
+
+	if err := validateStruct(v, t); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+func setField(fv reflect.Value, raw string) error {
+	switch {
+	case fv.Kind() == reflect.Ptr && fv.Type().Elem() == timeType:
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return fmt.Errorf("invalid RFC3339 timestamp: %w", err)
+		}
+		fv.Set(reflect.New(timeType))
+		fv.Elem().Set(reflect.ValueOf(parsed))
+		return nil
+	case fv.Type() == timeType:
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return fmt.Errorf("invalid RFC3339 timestamp: %w", err)
+		}
+		fv.Set(reflect.ValueOf(parsed))
+		return nil
+	case fv.Kind() == reflect.String:
+		fv.SetString(raw)
+		return nil
+	case fv.Kind() >= reflect.Int && fv.Kind() <= reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("not an integer: %w", err)
+		}
+		fv.SetInt(n)
+		return nil
+	case fv.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("not a bool: %w", err)
+		}
+		fv.SetBool(b)
+		return nil
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+}
+
+func validateStruct(v reflect.Value, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup("validate")
+		if !ok {
+			continue
+		}
+		fv := v.Field(i)
+		for _, rule := range strings.Split(tag, ",") {
+			if err := applyRule(field.Name, fv, rule); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func applyRule(fieldName string, fv reflect.Value, rule string) error {
+	name, arg, _ := strings.Cut(rule, "=")
+	switch name {
+	case "required":
+		if fv.IsZero() {
+			return &ValidationError{Field: fieldName, Rule: rule}
+		}
+	case "min":
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("validate tag on %s: bad min arg %q", fieldName, arg)
+		}
+		switch fv.Kind() {
+		case reflect.String:
+			if len(fv.String()) < n {
+				return &ValidationError{Field: fieldName, Rule: rule}
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if fv.Int() < int64(n) {
+				return &ValidationError{Field: fieldName, Rule: rule}
+			}
+		}
+	case "rfc3339":
+		if fv.Kind() == reflect.Ptr && fv.IsNil() {
+			return nil
+		}
+		s := fv
+		if s.Kind() == reflect.Ptr {
+			s = s.Elem()
+		}
+		if s.Kind() == reflect.String && s.String() != "" {
+			if _, err := time.Parse(time.RFC3339, s.String()); err != nil {
+				return &ValidationError{Field: fieldName, Rule: rule}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type createShiftRequest struct {
+	Title    string  `json:"title" validate:"required,min=2"`
+	StartsAt *string `json:"starts_at" validate:"rfc3339"`
+}
+
+type listShiftsRequest struct {
+	TenantID string `path:"tenant_id" validate:"required"`
+	Limit    int    `query:"limit"`
+}
+
+func TestBind_JSONBody(t *testing.T) {
+	cases := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{"valid", `{"title":"Night shift","starts_at":"2026-01-02T15:04:05Z"}`, false},
+		{"missing title", `{"starts_at":"2026-01-02T15:04:05Z"}`, true},
+		{"title too short", `{"title":"x"}`, true},
+		{"bad timestamp", `{"title":"Night shift","starts_at":"not-a-time"}`, true},
+		{"invalid json", `{`, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/api/shifts", strings.NewReader(tc.body))
+			_, err := Bind[createShiftRequest](r)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Bind() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestBind_PathAndQuery(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/api/tenants/t1/shifts?limit=10", nil)
+	r.SetPathValue("tenant_id", "t1")
+
+	got, err := Bind[listShiftsRequest](r)
+	if err != nil {
+		t.Fatalf("Bind() unexpected error: %v", err)
+	}
+	if got.TenantID != "t1" {
+		t.Errorf("TenantID = %q, want %q", got.TenantID, "t1")
+	}
+	if got.Limit != 10 {
+		t.Errorf("Limit = %d, want 10", got.Limit)
+	}
+}
+
+func TestBind_MissingRequiredPath(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/api/tenants//shifts", nil)
+	if _, err := Bind[listShiftsRequest](r); err == nil {
+		t.Fatal("expected error for missing required path value, got nil")
+	}
+}
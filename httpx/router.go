@@ -0,0 +1,97 @@
+// Package httpx wraps net/http's ServeMux with a small, declarative layer
+// for route registration and request binding, so handlers stop hand-rolling
+// JSON decoding and query/path parsing.
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Middleware wraps a handler to produce another handler.
+type Middleware func(http.Handler) http.Handler
+
+type routeTemplateKey struct{}
+
+// RouteTemplate returns the registered route pattern (e.g. "/api/shifts/{id}")
+// for the current request, for use as a low-cardinality metrics/log label —
+// unlike r.URL.Path, it doesn't vary per path-parameter value.
+func RouteTemplate(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(routeTemplateKey{}).(string)
+	return v, ok
+}
+
+func withRouteTemplate(template string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), routeTemplateKey{}, template)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Router is a thin wrapper around http.ServeMux that registers routes with
+// Go 1.22 method+pattern syntax and applies a shared middleware chain to
+// every route.
+type Router struct {
+	mux        *http.ServeMux
+	middleware []Middleware
+}
+
+// NewRouter builds an empty Router.
+func NewRouter() *Router {
+	return &Router{mux: http.NewServeMux()}
+}
+
+// Use appends middleware to the chain applied to every route registered
+// after this call. Middleware runs in the order it was added.
+func (rt *Router) Use(mw ...Middleware) {
+	rt.middleware = append(rt.middleware, mw...)
+}
+
+func (rt *Router) handle(pattern string, h http.HandlerFunc) {
+	var handler http.Handler = h
+	for i := len(rt.middleware) - 1; i >= 0; i-- {
+		handler = rt.middleware[i](handler)
+	}
+	handler = withRouteTemplate(pathFromPattern(pattern), handler)
+	rt.mux.Handle(pattern, handler)
+}
+
+// pathFromPattern strips the leading "METHOD " from a Go 1.22 ServeMux
+// pattern, leaving just the route template.
+func pathFromPattern(pattern string) string {
+	if i := strings.IndexByte(pattern, ' '); i >= 0 {
+		return pattern[i+1:]
+	}
+	return pattern
+}
+
+// GET registers h for GET requests matching pattern.
+func (rt *Router) GET(pattern string, h http.HandlerFunc) {
+	rt.handle("GET "+pattern, h)
+}
+
+// POST registers h for POST requests matching pattern.
+func (rt *Router) POST(pattern string, h http.HandlerFunc) {
+	rt.handle("POST "+pattern, h)
+}
+
+// PUT registers h for PUT requests matching pattern.
+func (rt *Router) PUT(pattern string, h http.HandlerFunc) {
+	rt.handle("PUT "+pattern, h)
+}
+
+// DELETE registers h for DELETE requests matching pattern.
+func (rt *Router) DELETE(pattern string, h http.HandlerFunc) {
+	rt.handle("DELETE "+pattern, h)
+}
+
+// Handle registers h for a raw method+pattern string (e.g. for routes that
+// don't need the shared middleware chain, such as health checks).
+func (rt *Router) Handle(pattern string, h http.Handler) {
+	rt.mux.Handle(pattern, h)
+}
+
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rt.mux.ServeHTTP(w, r)
+}
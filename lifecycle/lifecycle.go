@@ -0,0 +1,60 @@
+// Package lifecycle helps handlers cooperate with server shutdown: bounding
+// how long a single request may hold a downstream connection, and tracking
+// in-flight handlers so main can wait for them before tearing down shared
+// resources like the DB pool.
+package lifecycle
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WithTimeout wraps ctx with a deadline of d, mirroring the deadline/cancel
+// pattern used to bound a single socket operation: the caller gets back a
+// context that downstream calls (DB queries, outbound requests) should use
+// instead of the bare request context, so one slow operation can't outlive
+// the caller.
+func WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, d)
+}
+
+// Tracker counts in-flight requests and refuses new ones once draining has
+// started, so main can wait for handlers to finish before closing shared
+// resources during shutdown.
+type Tracker struct {
+	wg       sync.WaitGroup
+	draining atomic.Bool
+}
+
+// NewTracker builds an empty Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// Middleware rejects requests with 503 once Drain has been called, and
+// otherwise tracks the request for Wait.
+func (t *Tracker) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if t.draining.Load() {
+			http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		t.wg.Add(1)
+		defer t.wg.Done()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Drain marks the server as shutting down; subsequent requests are rejected
+// with 503 by Middleware.
+func (t *Tracker) Drain() {
+	t.draining.Store(true)
+}
+
+// Wait blocks until every request tracked by Middleware has completed.
+func (t *Tracker) Wait() {
+	t.wg.Wait()
+}
@@ -4,30 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
-)
-
-type Tenant struct {
-	ID string `json:"id"`
-	Name string `json:"name"`
-	CreatedAt time.Time `json:"created_at"`
-}
-
-type Shift struct {
-	ID string `json:"id"`
-	TenantID string `json:"tenant_id"`
-	Title string `json:"title"`
-	StartedAt *time.Time `json:"starts_at,omitempty"`
-	EndsAt *time.Time `json:"ends_at,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-}
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"small-backend/auth"
+	"small-backend/httpx"
+	"small-backend/lifecycle"
+	"small-backend/obs"
+)
 
 // json helpers
 func jsonOK(w http.ResponseWriter, v any) {
@@ -39,14 +33,96 @@ func jsonOK(w http.ResponseWriter, v any) {
 func jsonCreated(w http.ResponseWriter, v any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func jsonError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
 	_ = json.NewEncoder(w).Encode(map[string]any{"error": msg})
 }
 
+// bindErr writes the right status code for a httpx.Bind failure: validation
+// failures are client errors, anything else (malformed JSON) is a 400.
+func bindErr(w http.ResponseWriter, err error) {
+	var verr *httpx.ValidationError
+	if errors.As(err, &verr) {
+		jsonError(w, 422, err.Error())
+		return
+	}
+	jsonError(w, 400, err.Error())
+}
+
+// dbError maps a DB call failure to a response: a query that missed its
+// per-request deadline becomes a 504 rather than a generic 500, so clients
+// can distinguish "the server is overloaded/shutting down" from "bad data".
+func dbError(w http.ResponseWriter, err error) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		jsonError(w, http.StatusGatewayTimeout, "query timed out")
+		return
+	}
+	jsonError(w, 500, err.Error())
+}
+
+// etag renders an optimistic-concurrency version as a quoted ETag value.
+func etag(version int) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// ifMatchVersion parses the required If-Match header into the version it
+// names, for use in a WHERE ... AND version = $n compare-and-swap update.
+func ifMatchVersion(r *http.Request) (int, error) {
+	raw := strings.Trim(r.Header.Get("If-Match"), `"`)
+	if raw == "" {
+		return 0, errors.New("If-Match header is required")
+	}
+	version, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, errors.New("If-Match must be a quoted version number")
+	}
+	return version, nil
+}
+
+// preconditionFailedOrNotFound disambiguates a zero-rows optimistic-
+// concurrency write: if the row no longer exists (within the caller's
+// tenant), that's a 404; otherwise a concurrent writer changed it since the
+// caller's If-Match, a 412. table must be a hardcoded identifier, never
+// request-derived.
+func (a *App) preconditionFailedOrNotFound(w http.ResponseWriter, r *http.Request, table, id string) {
+	var query string
+	args := []any{id}
+	switch table {
+	case "tenants":
+		query = "SELECT 1 FROM tenants WHERE id = $1"
+	case "shifts":
+		tenantID, _ := auth.TenantIDFromContext(r.Context())
+		query = "SELECT 1 FROM shifts WHERE id = $1 AND tenant_id = $2"
+		args = append(args, tenantID)
+	default:
+		panic("preconditionFailedOrNotFound: unknown table " + table)
+	}
+
+	ctx, cancel := lifecycle.WithTimeout(r.Context(), a.DBTimeout)
+	defer cancel()
+
+	var exists int
+	err := a.DB.QueryRow(ctx, query, args...).Scan(&exists)
+	if errors.Is(err, pgx.ErrNoRows) {
+		jsonError(w, 404, table+" not found")
+		return
+	}
+	if err != nil {
+		dbError(w, err)
+		return
+	}
+	jsonError(w, 412, "version mismatch: resource was modified concurrently")
+}
+
 func withCORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, If-Match")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusNoContent)
 			return
@@ -55,198 +131,186 @@ func withCORS(next http.Handler) http.Handler {
 	})
 }
 
-
 type App struct {
-	DB *pgxpool.Pool
+	DB        *pgxpool.Pool
+	Auth      *auth.Service
+	DBTimeout time.Duration
+	Logger    *slog.Logger
 }
 
-// -------- Handlers -------------
 func (a *App) healthz(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("ok"))
 }
 
-// GET /api/tenants
-func (a *App) listTenants(w http.ResponseWriter, r *http.Request) {
-	rows, err := a.DB.Query(r.Context(),`
-		SELECT id::text, name, created_at
-		FROM tenants
-		ORDER BY created_at DESC`)
+type registerRequest struct {
+	TenantID string `json:"tenant_id" validate:"required"`
+	Email    string `json:"email" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+// POST /api/auth/register { "tenant_id": "UUID", "email": "...", "password": "..." }
+func (a *App) register(w http.ResponseWriter, r *http.Request) {
+	body, err := httpx.Bind[registerRequest](r)
 	if err != nil {
-		jsonError(w, 500, err.Error())
+		bindErr(w, err)
 		return
 	}
-	defer rows.Close()
 
-	var out []Tenant
-	for rows.Next() {
-		var t Tenant
-		if err := rows.Scan(&t.ID, &t.Name, &t.CreatedAt); err != nil {
-			jsonError(w, 500, err.Error())
+	ctx, cancel := lifecycle.WithTimeout(r.Context(), a.DBTimeout)
+	defer cancel()
+
+	token, err := a.Auth.Register(ctx, body.TenantID, body.Email, body.Password)
+	if err != nil {
+		if errors.Is(err, auth.ErrEmailTaken) {
+			jsonError(w, 409, err.Error())
 			return
 		}
-		out = append(out, t)
-	}
-	if err := rows.Err(); err != nil {
-		jsonError(w, 500, err.Error())
+		dbError(w, err)
 		return
 	}
-	jsonOK(w, out)
+	jsonCreated(w, map[string]string{"token": token})
 }
 
-// post /api/tenants { "name": "Acme Security"}
-func (a *App) createTenant(w http.ResponseWriter, r *http.Request) {
-	var body struct{ Name string `json:"name"`}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		jsonError(w, 400, "invalid json")
-		return
-	}
-	if len(body.Name) < 2 {
-		jsonError(w, 422, "name must be at least 2 characters")
-		return
-	}
-
-	var t Tenant
-	err := a.DB.QueryRow(r.Context(), `
-		INSERT INTO tenants (name)
-		VALUES ($1)
-		RETURNING id::text, name, created_at`,
-		body.Name,
-	).Scan(&t.ID, &t.Name, &t.CreatedAt)
-	if err != nil {
-		jsonError(w, 500, err.Error())
-	}
-	jsonCreated(w, t)
+type loginRequest struct {
+	Email    string `json:"email" validate:"required"`
+	Password string `json:"password" validate:"required"`
 }
 
-// GET /api/shifts?tenant_id=UUID
-func (a *App) listShifts(w http.ResponseWriter, r *http.Request) {
-	tenantID := r.URL.Query().Get("tenant_id")
-
-	query := `
-		SELECT id::text, tenant_id::text, title, starts_at, ends_at, created_at
-		FROM shifts`
-	args := []any{}
-	if tenantID != "" {
-		query += " WHERE tenant_id = $1"
-		args = append(args, tenantID)
-	}
-	query += " ORDER BY created_at DESC"
-
-	rows, err := a.DB.Query(r.Context(), query, args...)
+// POST /api/auth/login { "email": "...", "password": "..." }
+func (a *App) login(w http.ResponseWriter, r *http.Request) {
+	body, err := httpx.Bind[loginRequest](r)
 	if err != nil {
-		jsonError(w, 500, err.Error())
+		bindErr(w, err)
 		return
 	}
-	defer rows.Close()
 
-	var out []Shift
-	for rows.Next() {
-		var s Shift
-		if err := rows.Scan(&s.ID, &s.TenantID, &s.Title, &s.StartsAt, &s.EndsAt, &s.CreatedAt); err != nil {
-			jsonError(w, 500, err.Error())
+	ctx, cancel := lifecycle.WithTimeout(r.Context(), a.DBTimeout)
+	defer cancel()
+
+	token, err := a.Auth.Login(ctx, body.Email, body.Password)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			jsonError(w, 401, err.Error())
 			return
 		}
-		out = append(out, s)
-	}
-	if err := rows.Err(); err != nil {
-		jsonError(w, 500, err.Error())
+		dbError(w, err)
 		return
 	}
-	jsonOK(w, out)
+	jsonOK(w, map[string]string{"token": token})
 }
 
-// POST /api/shifts
-func (a *App) createShift(w http.ResponseWriter, r *http.Request) {
-	var body struct {
-		TenantID string `json:"tenant_id"`
-		Title string `json:"title"`
-		StartsAt *string `json:"starts_at"`
-		EndsAt *string `json:"ends_at"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		jsonError(w, 400, "invalid json")
-		return
+// main function
+
+// parseLogLevel maps LOG_LEVEL's conventional names to a slog.Level,
+// defaulting to info for an unset or unrecognized value.
+func parseLogLevel(s string) slog.Level {
+	switch strings.ToLower(s) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
 	}
-	if body.TenantID == "" || body.Title == "" {
-		jsonError(w, 422, "tenant_id and title required")
-		return
+}
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level: parseLogLevel(os.Getenv("LOG_LEVEL")),
+	}))
+
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		logger.Error("DATABASE_URL is not set")
+		os.Exit(1)
 	}
 
-	var st, et *time.Time
-	if body.StartsAt != nil && *body.StartsAt != "" {
-		t, err := time.Parse(time.RFC3339, *body.StartsAt)
-		if err != nil {
-			jsonError(w, 422, "invalid starts_at format (RFC3339)")
-			return
-		}
-		st = &t
+	signingKey := os.Getenv("JWT_SIGNING_KEY")
+	if signingKey == "" {
+		logger.Error("JWT_SIGNING_KEY is not set")
+		os.Exit(1)
 	}
-	if body.EndsAt != nil && *body.EndsAt != "" {
-		t, err := time.Parse(time.RFC3339, *body.EndsAt)
+	tokenTTL := 24 * time.Hour
+	if v := os.Getenv("JWT_TOKEN_TTL"); v != "" {
+		hours, err := strconv.Atoi(v)
 		if err != nil {
-			jsonError(w, 422, "invalid ends_at format (RFC3339)")
-			return
+			logger.Error("JWT_TOKEN_TTL must be an integer number of hours", "error", err)
+			os.Exit(1)
 		}
-		et = &t
+		tokenTTL = time.Duration(hours) * time.Hour
 	}
 
-	var s Shift
-	err := a.DB.QueryRow(r.Context(), `
-		INSERT INTO shifts (tenant_id, title, starts_at, ends_at)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id::text, tenant_id::text, title, starts_at, ends_at, created_at`,
-		body.TenantID, body.Title, st, et,
-	).Scan(&s.ID, &s.TenantID, &s.Title, &s.StartsAt, &s.EndsAt, &s.CreatedAt)
-	if err != nil {
-		jsonError(w, 500, err.Error())
-		return
-	}
-	jsonCreated(w, s)	
-}
-
-// main function
-
-func main() {
-	dbURL := os.Getenv("DATABASE_URL")
-	if dbURL == "" {
-		log.Fatal("DATABASE_URL is not set")
+	dbTimeout := 3 * time.Second
+	if v := os.Getenv("DB_QUERY_TIMEOUT"); v != "" {
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			logger.Error(`DB_QUERY_TIMEOUT must be a valid duration (e.g. "3s")`, "error", err)
+			os.Exit(1)
+		}
+		dbTimeout = parsed
 	}
 
 	pool, err := pgxpool.New(context.Background(), dbURL)
 	if err != nil {
-		log.Fatal(err)
+		logger.Error("connecting to database", "error", err)
+		os.Exit(1)
 	}
 	if err := pool.Ping(context.Background()); err != nil {
-		log.Fatal(err)
+		logger.Error("pinging database", "error", err)
+		os.Exit(1)
 	}
 	defer pool.Close()
 
-	app := &App{DB: pool}
-	mux := http.NewServeMux()
-	mux.HandleFunc("/healthz", app.healthz)
-	mux.Handle("GET /api/tenants", withCORS(http.HandlerFunc(app.listTenants)))
-	mux.Handle("POST /api/tenants", withCORS(http.HandlerFunc(app.createTenant)))
-	mux.Handle("GET /api/shifts", withCORS(http.HandlerFunc(app.listShifts)))
-	mux.Handle("POST /api/shifts", withCORS(http.HandlerFunc(app.createShift)))
+	authSvc := auth.NewService(pool, []byte(signingKey), tokenTTL)
+	app := &App{DB: pool, Auth: authSvc, DBTimeout: dbTimeout, Logger: logger}
+	tracker := lifecycle.NewTracker()
+
+	router := httpx.NewRouter()
+	router.Handle("/healthz", http.HandlerFunc(app.healthz))
+	router.Handle("/metrics", promhttp.Handler())
+
+	router.Use(obs.RequestID)
+	router.Use(obs.Log(logger))
+	router.Use(tracker.Middleware)
+	router.Use(withCORS)
+	router.POST("/api/auth/register", app.register)
+	router.POST("/api/auth/login", app.login)
+
+	router.Use(authSvc.RequireAuth)
+	router.GET("/api/tenants", app.listTenants)
+	router.POST("/api/tenants", app.createTenant)
+	router.GET("/api/tenants/{id}", app.getTenant)
+	router.PUT("/api/tenants/{id}", app.updateTenant)
+	router.DELETE("/api/tenants/{id}", app.deleteTenant)
+	router.GET("/api/shifts", app.listShifts)
+	router.POST("/api/shifts", app.createShift)
+	router.GET("/api/shifts/{id}", app.getShift)
+	router.PUT("/api/shifts/{id}", app.updateShift)
+	router.DELETE("/api/shifts/{id}", app.deleteShift)
 
 	srv := &http.Server{
-		Addr: ":8080",
-		Handler: mux,
+		Addr:              ":8080",
+		Handler:           router,
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 
 	go func() {
-		log.Println("API listening on :8080")
+		logger.Info("API listening", "addr", srv.Addr)
 		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Fatal(err)
+			logger.Error("server exited", "error", err)
+			os.Exit(1)
 		}
 	}()
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
 	<-stop
-	log.Println("shutting down...")
+	logger.Info("shutting down")
+	tracker.Drain()
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	_ = srv.Shutdown(ctx)
-}
\ No newline at end of file
+	tracker.Wait()
+}
@@ -0,0 +1,144 @@
+// Package obs provides request-scoped observability: propagating request
+// IDs, emitting one structured log line per request, and recording
+// Prometheus request-count/duration metrics.
+package obs
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"small-backend/httpx"
+)
+
+type ctxKey string
+
+const (
+	requestIDKey    ctxKey = "obs.request_id"
+	tenantHolderKey ctxKey = "obs.tenant_holder"
+)
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total HTTP requests processed, by route, method and status.",
+	}, []string{"route", "method", "status"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request duration in seconds, by route, method and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+)
+
+// RequestID propagates the caller's X-Request-ID header, generating a
+// UUIDv4 if absent, and injects it into the request context.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Request-ID")
+		if id == "" {
+			id = uuid.NewString()
+		}
+		w.Header().Set("X-Request-ID", id)
+		ctx := context.WithValue(r.Context(), requestIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext returns the request ID injected by RequestID.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(requestIDKey).(string)
+	return v, ok
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+// tenantHolder carries the authenticated tenant_id up out of a handler that
+// runs *inside* Log (e.g. auth middleware further down the chain). Context
+// values only flow downstream, so Log can't see what an inner middleware
+// later injects into its own child context; it instead hands every request
+// a mutable holder via the context, which SetTenant writes into and Log
+// reads back after next.ServeHTTP returns.
+type tenantHolder struct {
+	id string
+	ok bool
+}
+
+// SetTenant records the authenticated tenant for the current request, for
+// inclusion in the access-log line Log writes once the handler returns. A
+// no-op if the request wasn't wrapped by Log.
+func SetTenant(ctx context.Context, tenantID string) {
+	if h, ok := ctx.Value(tenantHolderKey).(*tenantHolder); ok {
+		h.id = tenantID
+		h.ok = true
+	}
+}
+
+// Log returns middleware that emits one structured access-log line per
+// request via logger, and records http_requests_total/http_request_duration_seconds
+// for every route/method/status combination.
+func Log(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusWriter{ResponseWriter: w}
+
+			holder := &tenantHolder{}
+			ctx := context.WithValue(r.Context(), tenantHolderKey, holder)
+			next.ServeHTTP(sw, r.WithContext(ctx))
+
+			status := sw.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			duration := time.Since(start)
+
+			route := r.URL.Path
+			if tmpl, ok := httpx.RouteTemplate(r.Context()); ok {
+				route = tmpl
+			}
+
+			requestsTotal.WithLabelValues(route, r.Method, strconv.Itoa(status)).Inc()
+			requestDuration.WithLabelValues(route, r.Method, strconv.Itoa(status)).Observe(duration.Seconds())
+
+			attrs := []any{
+				"method", r.Method,
+				"path", route,
+				"status", status,
+				"duration_ms", duration.Milliseconds(),
+				"bytes", sw.bytes,
+			}
+			if id, ok := RequestIDFromContext(r.Context()); ok {
+				attrs = append(attrs, "request_id", id)
+			}
+			if holder.ok {
+				attrs = append(attrs, "tenant_id", holder.id)
+			}
+			logger.Info("http_request", attrs...)
+		})
+	}
+}
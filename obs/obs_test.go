@@ -0,0 +1,106 @@
+package obs
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestID_GeneratesWhenAbsent(t *testing.T) {
+	var gotID string
+	h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID, _ = RequestIDFromContext(r.Context())
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if gotID == "" {
+		t.Fatal("expected a generated request ID in context")
+	}
+	if header := rec.Header().Get("X-Request-ID"); header != gotID {
+		t.Errorf("X-Request-ID header = %q, want %q", header, gotID)
+	}
+}
+
+func TestRequestID_PropagatesExisting(t *testing.T) {
+	h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-ID", "caller-supplied-id")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("X-Request-ID"); got != "caller-supplied-id" {
+		t.Errorf("X-Request-ID header = %q, want %q", got, "caller-supplied-id")
+	}
+}
+
+// requireAuthStub mimics auth.Service.RequireAuth: it runs *inside* Log and
+// calls SetTenant on the request context before invoking next, the same way
+// the real auth middleware does once it has validated a token.
+func requireAuthStub(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		SetTenant(r.Context(), "tenant-1")
+		next.ServeHTTP(w, r)
+	})
+}
+
+func decodeLogLine(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("decode log line: %v", err)
+	}
+	return line
+}
+
+func TestLog_RecordsTenantSetByInnerMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	h := Log(logger)(requireAuthStub(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("ok"))
+	})))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/shifts", nil))
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+
+	line := decodeLogLine(t, &buf)
+	if got := line["tenant_id"]; got != "tenant-1" {
+		t.Errorf("tenant_id = %v, want %q", got, "tenant-1")
+	}
+	if got := line["status"]; got != float64(http.StatusCreated) {
+		t.Errorf("status = %v, want %d", got, http.StatusCreated)
+	}
+}
+
+func TestLog_OmitsTenantForUnauthenticatedRoutes(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	h := Log(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/login", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	line := decodeLogLine(t, &buf)
+	if _, ok := line["tenant_id"]; ok {
+		t.Errorf("tenant_id = %v, want absent", line["tenant_id"])
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	want := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	encoded := encodeCursor(want, "shift-1")
+
+	gotTime, gotID, err := decodeCursor(encoded)
+	if err != nil {
+		t.Fatalf("decodeCursor() error = %v", err)
+	}
+	if !gotTime.Equal(want) {
+		t.Errorf("CreatedAt = %v, want %v", gotTime, want)
+	}
+	if gotID != "shift-1" {
+		t.Errorf("ID = %q, want %q", gotID, "shift-1")
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	if _, _, err := decodeCursor("not-valid-base64!!"); err == nil {
+		t.Fatal("expected error for malformed cursor, got nil")
+	}
+}
+
+func TestClampLimit(t *testing.T) {
+	cases := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{"zero uses default", 0, defaultPageLimit},
+		{"negative uses default", -5, defaultPageLimit},
+		{"within range is kept", 50, 50},
+		{"over max is capped", 500, maxPageLimit},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := clampLimit(tc.limit); got != tc.want {
+				t.Errorf("clampLimit(%d) = %d, want %d", tc.limit, got, tc.want)
+			}
+		})
+	}
+}
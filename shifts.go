@@ -0,0 +1,246 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"small-backend/auth"
+	"small-backend/httpx"
+	"small-backend/lifecycle"
+)
+
+type Shift struct {
+	ID        string     `json:"id"`
+	TenantID  string     `json:"tenant_id"`
+	Title     string     `json:"title"`
+	Version   int        `json:"version"`
+	StartsAt  *time.Time `json:"starts_at,omitempty"`
+	EndsAt    *time.Time `json:"ends_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type listShiftsRequest struct {
+	Limit        int        `query:"limit"`
+	Cursor       string     `query:"cursor"`
+	Q            string     `query:"q"`
+	StartsAfter  *time.Time `query:"starts_after"`
+	StartsBefore *time.Time `query:"starts_before"`
+}
+
+// GET /api/shifts?limit=&cursor=&q=&starts_after=&starts_before= — scoped
+// to the caller's tenant, keyset-paginated on (created_at, id).
+func (a *App) listShifts(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := auth.TenantIDFromContext(r.Context())
+
+	req, err := httpx.Bind[listShiftsRequest](r)
+	if err != nil {
+		bindErr(w, err)
+		return
+	}
+	limit := clampLimit(req.Limit)
+
+	conds := []string{"tenant_id = $1"}
+	args := []any{tenantID}
+	if req.Q != "" {
+		args = append(args, req.Q)
+		conds = append(conds, fmt.Sprintf("to_tsvector('simple', title) @@ plainto_tsquery('simple', $%d)", len(args)))
+	}
+	if req.StartsAfter != nil {
+		args = append(args, *req.StartsAfter)
+		conds = append(conds, fmt.Sprintf("starts_at > $%d", len(args)))
+	}
+	if req.StartsBefore != nil {
+		args = append(args, *req.StartsBefore)
+		conds = append(conds, fmt.Sprintf("starts_at < $%d", len(args)))
+	}
+	if req.Cursor != "" {
+		createdAt, id, err := decodeCursor(req.Cursor)
+		if err != nil {
+			jsonError(w, 422, err.Error())
+			return
+		}
+		args = append(args, createdAt, id)
+		conds = append(conds, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf(`
+		SELECT id::text, tenant_id::text, title, version, starts_at, ends_at, created_at
+		FROM shifts
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d`, strings.Join(conds, " AND "), len(args))
+
+	ctx, cancel := lifecycle.WithTimeout(r.Context(), a.DBTimeout)
+	defer cancel()
+
+	rows, err := a.DB.Query(ctx, query, args...)
+	if err != nil {
+		dbError(w, err)
+		return
+	}
+	defer rows.Close()
+
+	var out []Shift
+	for rows.Next() {
+		var s Shift
+		if err := rows.Scan(&s.ID, &s.TenantID, &s.Title, &s.Version, &s.StartsAt, &s.EndsAt, &s.CreatedAt); err != nil {
+			dbError(w, err)
+			return
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		dbError(w, err)
+		return
+	}
+
+	var nextCursor string
+	if len(out) > limit {
+		last := out[limit-1]
+		nextCursor = encodeCursor(last.CreatedAt, last.ID)
+		out = out[:limit]
+	}
+	jsonOK(w, Page[Shift]{Items: out, NextCursor: nextCursor})
+}
+
+type createShiftRequest struct {
+	Title    string     `json:"title" validate:"required"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+}
+
+// POST /api/shifts — created in the caller's tenant
+func (a *App) createShift(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := auth.TenantIDFromContext(r.Context())
+
+	body, err := httpx.Bind[createShiftRequest](r)
+	if err != nil {
+		bindErr(w, err)
+		return
+	}
+
+	ctx, cancel := lifecycle.WithTimeout(r.Context(), a.DBTimeout)
+	defer cancel()
+
+	var s Shift
+	err = a.DB.QueryRow(ctx, `
+		INSERT INTO shifts (tenant_id, title, starts_at, ends_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id::text, tenant_id::text, title, version, starts_at, ends_at, created_at`,
+		tenantID, body.Title, body.StartsAt, body.EndsAt,
+	).Scan(&s.ID, &s.TenantID, &s.Title, &s.Version, &s.StartsAt, &s.EndsAt, &s.CreatedAt)
+	if err != nil {
+		dbError(w, err)
+		return
+	}
+	jsonCreated(w, s)
+}
+
+// GET /api/shifts/{id}
+func (a *App) getShift(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := auth.TenantIDFromContext(r.Context())
+	id := r.PathValue("id")
+
+	ctx, cancel := lifecycle.WithTimeout(r.Context(), a.DBTimeout)
+	defer cancel()
+
+	var s Shift
+	err := a.DB.QueryRow(ctx, `
+		SELECT id::text, tenant_id::text, title, version, starts_at, ends_at, created_at
+		FROM shifts
+		WHERE id = $1 AND tenant_id = $2`,
+		id, tenantID,
+	).Scan(&s.ID, &s.TenantID, &s.Title, &s.Version, &s.StartsAt, &s.EndsAt, &s.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		jsonError(w, 404, "shift not found")
+		return
+	}
+	if err != nil {
+		dbError(w, err)
+		return
+	}
+	w.Header().Set("ETag", etag(s.Version))
+	jsonOK(w, s)
+}
+
+type updateShiftRequest struct {
+	Title    string     `json:"title" validate:"required"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+}
+
+// PUT /api/shifts/{id} — requires If-Match
+func (a *App) updateShift(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := auth.TenantIDFromContext(r.Context())
+	id := r.PathValue("id")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		jsonError(w, 428, err.Error())
+		return
+	}
+
+	body, err := httpx.Bind[updateShiftRequest](r)
+	if err != nil {
+		bindErr(w, err)
+		return
+	}
+
+	ctx, cancel := lifecycle.WithTimeout(r.Context(), a.DBTimeout)
+	defer cancel()
+
+	var s Shift
+	err = a.DB.QueryRow(ctx, `
+		UPDATE shifts
+		SET title = $1, starts_at = $2, ends_at = $3, version = version + 1
+		WHERE id = $4 AND tenant_id = $5 AND version = $6
+		RETURNING id::text, tenant_id::text, title, version, starts_at, ends_at, created_at`,
+		body.Title, body.StartsAt, body.EndsAt, id, tenantID, version,
+	).Scan(&s.ID, &s.TenantID, &s.Title, &s.Version, &s.StartsAt, &s.EndsAt, &s.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		a.preconditionFailedOrNotFound(w, r, "shifts", id)
+		return
+	}
+	if err != nil {
+		dbError(w, err)
+		return
+	}
+	w.Header().Set("ETag", etag(s.Version))
+	jsonOK(w, s)
+}
+
+// DELETE /api/shifts/{id} — requires If-Match
+func (a *App) deleteShift(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := auth.TenantIDFromContext(r.Context())
+	id := r.PathValue("id")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		jsonError(w, 428, err.Error())
+		return
+	}
+
+	ctx, cancel := lifecycle.WithTimeout(r.Context(), a.DBTimeout)
+	defer cancel()
+
+	tag, err := a.DB.Exec(ctx, `
+		DELETE FROM shifts
+		WHERE id = $1 AND tenant_id = $2 AND version = $3`,
+		id, tenantID, version,
+	)
+	if err != nil {
+		dbError(w, err)
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		a.preconditionFailedOrNotFound(w, r, "shifts", id)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"small-backend/auth"
+	"small-backend/httpx"
+	"small-backend/lifecycle"
+)
+
+type Tenant struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type listTenantsRequest struct {
+	Limit  int    `query:"limit"`
+	Cursor string `query:"cursor"`
+	Q      string `query:"q"`
+}
+
+// GET /api/tenants?limit=&cursor=&q= — returns the caller's own tenant,
+// keyset-paginated on (created_at, id) for consistency with listShifts.
+func (a *App) listTenants(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := auth.TenantIDFromContext(r.Context())
+
+	req, err := httpx.Bind[listTenantsRequest](r)
+	if err != nil {
+		bindErr(w, err)
+		return
+	}
+	limit := clampLimit(req.Limit)
+
+	conds := []string{"id = $1"}
+	args := []any{tenantID}
+	if req.Q != "" {
+		args = append(args, req.Q)
+		conds = append(conds, fmt.Sprintf("to_tsvector('simple', name) @@ plainto_tsquery('simple', $%d)", len(args)))
+	}
+	if req.Cursor != "" {
+		createdAt, id, err := decodeCursor(req.Cursor)
+		if err != nil {
+			jsonError(w, 422, err.Error())
+			return
+		}
+		args = append(args, createdAt, id)
+		conds = append(conds, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf(`
+		SELECT id::text, name, version, created_at
+		FROM tenants
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d`, strings.Join(conds, " AND "), len(args))
+
+	ctx, cancel := lifecycle.WithTimeout(r.Context(), a.DBTimeout)
+	defer cancel()
+
+	rows, err := a.DB.Query(ctx, query, args...)
+	if err != nil {
+		dbError(w, err)
+		return
+	}
+	defer rows.Close()
+
+	var out []Tenant
+	for rows.Next() {
+		var t Tenant
+		if err := rows.Scan(&t.ID, &t.Name, &t.Version, &t.CreatedAt); err != nil {
+			dbError(w, err)
+			return
+		}
+		out = append(out, t)
+	}
+	if err := rows.Err(); err != nil {
+		dbError(w, err)
+		return
+	}
+
+	var nextCursor string
+	if len(out) > limit {
+		last := out[limit-1]
+		nextCursor = encodeCursor(last.CreatedAt, last.ID)
+		out = out[:limit]
+	}
+	jsonOK(w, Page[Tenant]{Items: out, NextCursor: nextCursor})
+}
+
+type createTenantRequest struct {
+	Name string `json:"name" validate:"required,min=2"`
+}
+
+// POST /api/tenants { "name": "Acme Security" }
+func (a *App) createTenant(w http.ResponseWriter, r *http.Request) {
+	body, err := httpx.Bind[createTenantRequest](r)
+	if err != nil {
+		bindErr(w, err)
+		return
+	}
+
+	ctx, cancel := lifecycle.WithTimeout(r.Context(), a.DBTimeout)
+	defer cancel()
+
+	var t Tenant
+	err = a.DB.QueryRow(ctx, `
+		INSERT INTO tenants (name)
+		VALUES ($1)
+		RETURNING id::text, name, version, created_at`,
+		body.Name,
+	).Scan(&t.ID, &t.Name, &t.Version, &t.CreatedAt)
+	if err != nil {
+		dbError(w, err)
+		return
+	}
+	jsonCreated(w, t)
+}
+
+// GET /api/tenants/{id}
+func (a *App) getTenant(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := auth.TenantIDFromContext(r.Context())
+	id := r.PathValue("id")
+	if id != tenantID {
+		jsonError(w, 403, "cannot access another tenant")
+		return
+	}
+
+	ctx, cancel := lifecycle.WithTimeout(r.Context(), a.DBTimeout)
+	defer cancel()
+
+	var t Tenant
+	err := a.DB.QueryRow(ctx, `
+		SELECT id::text, name, version, created_at
+		FROM tenants
+		WHERE id = $1`,
+		id,
+	).Scan(&t.ID, &t.Name, &t.Version, &t.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		jsonError(w, 404, "tenant not found")
+		return
+	}
+	if err != nil {
+		dbError(w, err)
+		return
+	}
+	w.Header().Set("ETag", etag(t.Version))
+	jsonOK(w, t)
+}
+
+type updateTenantRequest struct {
+	Name string `json:"name" validate:"required,min=2"`
+}
+
+// PUT /api/tenants/{id} — requires If-Match
+func (a *App) updateTenant(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := auth.TenantIDFromContext(r.Context())
+	id := r.PathValue("id")
+	if id != tenantID {
+		jsonError(w, 403, "cannot modify another tenant")
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		jsonError(w, 428, err.Error())
+		return
+	}
+
+	body, err := httpx.Bind[updateTenantRequest](r)
+	if err != nil {
+		bindErr(w, err)
+		return
+	}
+
+	ctx, cancel := lifecycle.WithTimeout(r.Context(), a.DBTimeout)
+	defer cancel()
+
+	var t Tenant
+	err = a.DB.QueryRow(ctx, `
+		UPDATE tenants
+		SET name = $1, version = version + 1
+		WHERE id = $2 AND version = $3
+		RETURNING id::text, name, version, created_at`,
+		body.Name, id, version,
+	).Scan(&t.ID, &t.Name, &t.Version, &t.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		a.preconditionFailedOrNotFound(w, r, "tenants", id)
+		return
+	}
+	if err != nil {
+		dbError(w, err)
+		return
+	}
+	w.Header().Set("ETag", etag(t.Version))
+	jsonOK(w, t)
+}
+
+// DELETE /api/tenants/{id} — requires If-Match
+func (a *App) deleteTenant(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := auth.TenantIDFromContext(r.Context())
+	id := r.PathValue("id")
+	if id != tenantID {
+		jsonError(w, 403, "cannot delete another tenant")
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		jsonError(w, 428, err.Error())
+		return
+	}
+
+	ctx, cancel := lifecycle.WithTimeout(r.Context(), a.DBTimeout)
+	defer cancel()
+
+	tag, err := a.DB.Exec(ctx, `
+		DELETE FROM tenants
+		WHERE id = $1 AND version = $2`,
+		id, version,
+	)
+	if err != nil {
+		dbError(w, err)
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		a.preconditionFailedOrNotFound(w, r, "tenants", id)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}